@@ -1,16 +1,75 @@
 package api
 
 import (
+	"fmt"
 	"io"
 	"log"
 	"regexp"
 	"sort"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/PuerkitoBio/goquery"
 	"github.com/pkg/errors"
 )
 
+// Episode represents a single episode of an anime, along with whatever
+// metadata the scraper was able to recover for it.
+//
+// Number, Num and URL are always populated by GetAnimeEpisodes, and Part is
+// set when the label carries a half-episode suffix. The metadata fields
+// (Title through Tag) are best-effort: they are only filled in by the
+// detailed parsing paths (GetAnimeEpisodesDetailed, GetAnimeEpisodesConcurrent)
+// and may be left at their zero value when the source page doesn't expose them.
+type Episode struct {
+	Number string
+	Num    int
+	URL    string
+	// Part is the fractional half of a half-episode (e.g. 5 for "12.5"), or 0
+	// for a regular, whole episode.
+	Part int
+
+	// Title is the episode's display title, if the source page exposes one.
+	Title string
+	// TitleJP is the Japanese (or other original-language) title.
+	TitleJP string
+	// AirDate is the episode's original air date. Zero value means unknown.
+	AirDate time.Time
+	// ThumbnailURL points at a preview image for the episode, if any.
+	ThumbnailURL string
+	// Duration is the episode's runtime, if the source page exposes it.
+	Duration time.Duration
+	// Tag classifies the episode as "filler", "recap", "special", "OVA", etc.
+	// An empty Tag means the episode is a regular episode.
+	Tag string
+}
+
+// EpisodeLess compares two episodes and reports whether a should sort before b.
+// It is used to parameterize sortEpisodes so callers can order episodes by
+// number, air date, or any other criteria without duplicating the sort logic.
+type EpisodeLess func(a, b Episode) bool
+
+// ByNumber orders episodes by their numeric episode number.
+func ByNumber(a, b Episode) bool {
+	return a.Num < b.Num
+}
+
+// ByAirDate orders episodes by air date, oldest first. Episodes with an
+// unknown (zero) air date sort after every episode with a known date.
+func ByAirDate(a, b Episode) bool {
+	if a.AirDate.IsZero() && b.AirDate.IsZero() {
+		return a.Num < b.Num
+	}
+	if a.AirDate.IsZero() {
+		return false
+	}
+	if b.AirDate.IsZero() {
+		return true
+	}
+	return a.AirDate.Before(b.AirDate)
+}
+
 // GetAnimeEpisodes fetches and parses the list of episodes for a given anime.
 // It returns a sorted slice of Episode structs, ordered by episode number.
 //
@@ -21,6 +80,53 @@ import (
 // - []Episode: a slice of Episode structs, sorted by episode number.
 // - error: an error if the process fails at any step.
 func GetAnimeEpisodes(animeURL string) ([]Episode, error) {
+	doc, err := fetchAnimeDocument(animeURL)
+	if err != nil {
+		return nil, err
+	}
+
+	// Extract the episodes from the parsed HTML document.
+	episodes := parseEpisodes(doc)
+	// Sort the episodes by their numerical order.
+	sortEpisodesByNum(episodes)
+
+	// Return the sorted list of episodes.
+	return episodes, nil
+}
+
+// GetAnimeEpisodesDetailed fetches the episode list for a given anime and
+// enriches each episode with the metadata exposed on the anime page's
+// per-episode detail nodes: title, air date, thumbnail, duration and tag.
+//
+// Parameters:
+// - animeURL: the URL of the anime's page.
+//
+// Returns:
+// - []Episode: a slice of Episode structs with detailed metadata, sorted by episode number.
+// - error: an error if the process fails at any step.
+func GetAnimeEpisodesDetailed(animeURL string) ([]Episode, error) {
+	doc, err := fetchAnimeDocument(animeURL)
+	if err != nil {
+		return nil, err
+	}
+
+	episodes := parseEpisodes(doc)
+	parseEpisodeDetails(doc, episodes)
+	sortEpisodesByNum(episodes)
+
+	return episodes, nil
+}
+
+// fetchAnimeDocument requests the anime page and parses it into a goquery
+// document, closing the response body once parsing is done.
+//
+// Parameters:
+// - animeURL: the URL of the anime's page.
+//
+// Returns:
+// - *goquery.Document: the parsed HTML document.
+// - error: an error if the request or parsing fails.
+func fetchAnimeDocument(animeURL string) (*goquery.Document, error) {
 	// Send an HTTP GET request to retrieve the anime details.
 	resp, err := SafeGet(animeURL)
 	if err != nil {
@@ -34,19 +140,33 @@ func GetAnimeEpisodes(animeURL string) ([]Episode, error) {
 		}
 	}(resp.Body)
 
+	if resp.StatusCode >= 400 {
+		return nil, &httpStatusError{url: animeURL, statusCode: resp.StatusCode}
+	}
+
 	// Parse the HTML response using goquery.
 	doc, err := goquery.NewDocumentFromReader(resp.Body)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to parse anime details")
 	}
+	return doc, nil
+}
 
-	// Extract the episodes from the parsed HTML document.
-	episodes := parseEpisodes(doc)
-	// Sort the episodes by their numerical order.
-	sortEpisodesByNum(episodes)
+// httpStatusError reports that a fetch came back with a non-2xx/3xx status,
+// so callers (e.g. the retry logic in GetAnimeEpisodesConcurrent) can tell a
+// transient 429/5xx apart from a network or parse failure.
+type httpStatusError struct {
+	url        string
+	statusCode int
+}
 
-	// Return the sorted list of episodes.
-	return episodes, nil
+func (e *httpStatusError) Error() string {
+	return fmt.Sprintf("unexpected status %d fetching %s", e.statusCode, e.url)
+}
+
+// StatusCode returns the HTTP status code the fetch came back with.
+func (e *httpStatusError) StatusCode() int {
+	return e.statusCode
 }
 
 // parseEpisodes extracts a list of Episode structs from the given goquery.Document.
@@ -74,17 +194,158 @@ func parseEpisodes(doc *goquery.Document) []Episode {
 		}
 
 		// Append the parsed episode information to the episodes slice.
-		episodes = append(episodes, Episode{
+		episode := Episode{
 			Number: episodeNum,
 			Num:    num,
 			URL:    episodeURL,
-		})
+		}
+		if parsed, err := GetParser(episodeURL).Parse(episodeNum); err == nil {
+			episode.Part = parsed.Part
+		}
+		episodes = append(episodes, episode)
 	})
 	return episodes
 }
 
+// parseEpisodeDetails walks the per-episode detail nodes of the anime page
+// (the ".episode-list-data" rows that sit alongside the plain episode links)
+// and fills in Title, TitleJP, AirDate, ThumbnailURL, Duration and Tag on the
+// matching entry of episodes, matched up by episode URL.
+//
+// Parameters:
+// - doc: a pointer to a goquery.Document which represents the parsed HTML content.
+// - episodes: the episodes previously extracted by parseEpisodes; updated in place.
+func parseEpisodeDetails(doc *goquery.Document, episodes []Episode) {
+	byURL := make(map[string]*Episode, len(episodes))
+	for i := range episodes {
+		byURL[episodes[i].URL] = &episodes[i]
+	}
+
+	doc.Find(".episode-list-data").Each(func(i int, s *goquery.Selection) {
+		link := s.Find("a")
+		episodeURL, _ := link.Attr("href")
+		ep, ok := byURL[episodeURL]
+		if !ok {
+			return
+		}
+		applyEpisodeDetail(s, ep)
+	})
+}
+
+// parseEpisodeDetail locates the detail node matching ep.URL in doc and, if
+// found, fills in ep's Title, TitleJP, AirDate, ThumbnailURL, Duration and
+// Tag in place. Unlike parseEpisodeDetails it enriches a single, already
+// known episode rather than matching a whole list up by URL, which lets
+// callers that only hold a *Episode (e.g. a concurrent worker) mutate it
+// directly instead of through a throwaway copy.
+//
+// Parameters:
+// - doc: a pointer to a goquery.Document which represents the parsed HTML content.
+// - ep: the episode to enrich in place; its URL is used to find the matching detail node.
+func parseEpisodeDetail(doc *goquery.Document, ep *Episode) {
+	doc.Find(".episode-list-data").EachWithBreak(func(i int, s *goquery.Selection) bool {
+		episodeURL, _ := s.Find("a").Attr("href")
+		if episodeURL != ep.URL {
+			return true
+		}
+		applyEpisodeDetail(s, ep)
+		return false
+	})
+}
+
+// applyEpisodeDetail fills in ep's Title, TitleJP, AirDate, ThumbnailURL,
+// Duration and Tag from a single ".episode-list-data" node.
+//
+// Parameters:
+// - s: the goquery selection for the episode's detail node.
+// - ep: the episode to enrich in place.
+func applyEpisodeDetail(s *goquery.Selection, ep *Episode) {
+	ep.Title = strings.TrimSpace(s.Find(".episode-title").Text())
+	ep.TitleJP = strings.TrimSpace(s.Find(".episode-title-jp").Text())
+	ep.Duration = parseEpisodeDuration(strings.TrimSpace(s.Find(".episode-duration").Text()))
+	ep.Tag = strings.TrimSpace(s.Find(".episode-tag").Text())
+
+	if thumb, ok := s.Find("img.episode-thumb").Attr("src"); ok {
+		ep.ThumbnailURL = thumb
+	}
+
+	if dateText := strings.TrimSpace(s.Find(".episode-air-date").Text()); dateText != "" {
+		if airDate, err := parseAirDate(dateText); err != nil {
+			log.Printf("Error parsing air date '%s': %v", dateText, err)
+		} else {
+			ep.AirDate = airDate
+		}
+	}
+}
+
+// airDateLayouts lists the date formats used by the sites this package
+// scrapes, tried in order until one parses successfully.
+var airDateLayouts = []string{
+	"2006-01-02",
+	"02/01/2006",
+	"Jan 2, 2006",
+	"2 Jan 2006",
+}
+
+// parseAirDate parses an episode air date using the known site layouts.
+//
+// Parameters:
+// - dateText: the raw air date text scraped from the page.
+//
+// Returns:
+// - time.Time: the parsed air date.
+// - error: an error if dateText doesn't match any known layout.
+func parseAirDate(dateText string) (time.Time, error) {
+	var lastErr error
+	for _, layout := range airDateLayouts {
+		if t, err := time.Parse(layout, dateText); err == nil {
+			return t, nil
+		} else {
+			lastErr = err
+		}
+	}
+	return time.Time{}, errors.Wrap(lastErr, "unrecognized air date format")
+}
+
+// episodeDurationRe matches an "Xm" or "Xmin" style duration string.
+var episodeDurationRe = regexp.MustCompile(`(\d+)\s*m(in)?`)
+
+// episodeDurationHHMMRe matches an "HH:MM" style duration string.
+var episodeDurationHHMMRe = regexp.MustCompile(`^(\d+):(\d{2})$`)
+
+// parseEpisodeDuration parses a scraped duration string into a time.Duration.
+// It understands "Xm"/"Xmin" labels (e.g. "24min") as well as "HH:MM" labels
+// (e.g. "00:24"), and returns 0 when the text matches neither.
+//
+// Parameters:
+// - durationText: the raw duration text scraped from the page.
+//
+// Returns:
+// - time.Duration: the parsed duration, or 0 if it couldn't be parsed.
+func parseEpisodeDuration(durationText string) time.Duration {
+	if m := episodeDurationHHMMRe.FindStringSubmatch(durationText); m != nil {
+		hours, errH := strconv.Atoi(m[1])
+		minutes, errM := strconv.Atoi(m[2])
+		if errH == nil && errM == nil {
+			return time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute
+		}
+	}
+
+	if m := episodeDurationRe.FindStringSubmatch(durationText); m != nil {
+		minutes, err := strconv.Atoi(m[1])
+		if err == nil {
+			return time.Duration(minutes) * time.Minute
+		}
+	}
+
+	return 0
+}
+
 // parseEpisodeNumber extracts the numeric portion of an episode number string.
-// It uses a regular expression to find the first sequence of digits and returns it as an integer.
+// For "S01E12" style labels it returns the episode-in-season part (12, not
+// the season 01); for everything else it uses the first sequence of digits
+// found, which already does the right thing for "Especial 3", "Filme 2" and
+// "OVA 1" style labels.
 //
 // Parameters:
 // - episodeNum: the string containing the episode number.
@@ -93,6 +354,10 @@ func parseEpisodes(doc *goquery.Document) []Episode {
 // - int: the parsed episode number.
 // - error: an error if the string cannot be converted to an integer.
 func parseEpisodeNumber(episodeNum string) (int, error) {
+	if m := seasonEpisodeRe.FindStringSubmatch(episodeNum); m != nil {
+		return strconv.Atoi(m[2])
+	}
+
 	// Regular expression to find the first sequence of digits in the episode number string.
 	numRe := regexp.MustCompile(`\d+`)
 	numStr := numRe.FindString(episodeNum)
@@ -109,9 +374,18 @@ func parseEpisodeNumber(episodeNum string) (int, error) {
 // Parameters:
 // - episodes: a slice of Episode structs to be sorted.
 func sortEpisodesByNum(episodes []Episode) {
-	// Sort the episodes slice in place using the sort.Slice function.
-	// The sorting is done based on the Num field of each Episode struct.
-	sort.Slice(episodes, func(i, j int) bool {
-		return episodes[i].Num < episodes[j].Num
+	sortEpisodes(episodes, ByNumber)
+}
+
+// sortEpisodes sorts a slice of Episode structs in place using the given
+// comparator, so callers can order by episode number, air date, or any other
+// criteria without duplicating the sort.Slice boilerplate.
+//
+// Parameters:
+// - episodes: a slice of Episode structs to be sorted.
+// - less: the comparator used to order the episodes.
+func sortEpisodes(episodes []Episode, less EpisodeLess) {
+	sort.SliceStable(episodes, func(i, j int) bool {
+		return less(episodes[i], episodes[j])
 	})
-}
\ No newline at end of file
+}
@@ -0,0 +1,221 @@
+package api
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+)
+
+// maxFetchRetries is how many attempts in all (the first try plus retries) a
+// single episode detail fetch gets on 429 or 5xx responses before it's given up on.
+const maxFetchRetries = 3
+
+// perHostMinInterval is the minimum spacing enforced between requests to the
+// same host, so a worker pool fetching many episode pages at once doesn't
+// hammer the origin site.
+const perHostMinInterval = 200 * time.Millisecond
+
+// GetAnimeEpisodesConcurrent fetches the episode list for animeURL and then
+// enriches every episode with its detail-page metadata concurrently, using a
+// bounded pool of workers instead of one request per episode in sequence.
+//
+// Per-host request spacing is enforced across workers, and an episode detail
+// fetch that comes back 429/5xx is retried with exponential backoff. A
+// failure to enrich one episode doesn't abort the scrape: individual errors
+// are collected into a *multierror.Error and returned alongside the full
+// episode list (episodes whose fetch failed keep only their basic fields),
+// still sorted by episode number.
+//
+// Parameters:
+// - animeURL: the URL of the anime's page.
+// - workers: the number of concurrent detail-fetch workers to run; values < 1 are treated as 1.
+//
+// Returns:
+// - []Episode: the episodes, enriched where possible, sorted by episode number.
+// - error: the error from fetching the episode list itself, else a *multierror.Error aggregating any per-episode fetch failures, or nil if every fetch succeeded.
+func GetAnimeEpisodesConcurrent(animeURL string, workers int) ([]Episode, error) {
+	return getAnimeEpisodesConcurrent(context.Background(), animeURL, workers)
+}
+
+func getAnimeEpisodesConcurrent(ctx context.Context, animeURL string, workers int) ([]Episode, error) {
+	if workers < 1 {
+		workers = 1
+	}
+
+	episodes, err := GetAnimeEpisodes(animeURL)
+	if err != nil {
+		return nil, err
+	}
+
+	limiter := newHostRateLimiter(perHostMinInterval)
+
+	jobs := make(chan int, len(episodes))
+	for i := range episodes {
+		jobs <- i
+	}
+	close(jobs)
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		fetchErr *multierror.Error
+	)
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				select {
+				case <-ctx.Done():
+					mu.Lock()
+					fetchErr = multierror.Append(fetchErr, ctx.Err())
+					mu.Unlock()
+					continue
+				default:
+				}
+
+				if err := fetchEpisodeDetailWithRetry(ctx, limiter, &episodes[i]); err != nil {
+					mu.Lock()
+					fetchErr = multierror.Append(fetchErr, err)
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	sortEpisodesByNum(episodes)
+
+	if fetchErr != nil {
+		return episodes, fetchErr.ErrorOrNil()
+	}
+	return episodes, nil
+}
+
+// fetchEpisodeDetailWithRetry fetches and applies detail-page metadata for a
+// single episode, retrying with exponential backoff when the response is a
+// 429 or 5xx, up to maxFetchRetries attempts.
+//
+// Parameters:
+// - ctx: the context governing cancellation of the fetch and its retries.
+// - limiter: the per-host rate limiter to wait on before each attempt.
+// - ep: the episode to enrich in place.
+//
+// Returns:
+// - error: the last error encountered, or nil on success.
+func fetchEpisodeDetailWithRetry(ctx context.Context, limiter *hostRateLimiter, ep *Episode) error {
+	var lastErr error
+
+	for attempt := 0; attempt < maxFetchRetries; attempt++ {
+		if err := limiter.Wait(ctx, ep.URL); err != nil {
+			return err
+		}
+
+		doc, err := fetchAnimeDocument(ep.URL)
+		if err != nil {
+			lastErr = err
+			if !isRetryableFetchError(err) {
+				return lastErr
+			}
+			backoff(ctx, attempt)
+			continue
+		}
+
+		parseEpisodeDetail(doc, ep)
+		return nil
+	}
+
+	return lastErr
+}
+
+// isRetryableFetchError reports whether an episode detail fetch should be
+// retried, i.e. fetchAnimeDocument came back with a 429 or 5xx status.
+// Network errors and parse failures surface without a status code and are
+// treated as permanent, since retrying them blindly would just mask the
+// underlying problem.
+func isRetryableFetchError(err error) bool {
+	httpErr, ok := err.(interface{ StatusCode() int })
+	if !ok {
+		return false
+	}
+	code := httpErr.StatusCode()
+	return code == http.StatusTooManyRequests || code >= http.StatusInternalServerError
+}
+
+// backoff sleeps for an exponentially increasing, jittered delay before the
+// next retry attempt, or returns early if ctx is cancelled.
+func backoff(ctx context.Context, attempt int) {
+	delay := time.Duration(1<<uint(attempt)) * 250 * time.Millisecond
+	delay += time.Duration(rand.Int63n(int64(100 * time.Millisecond)))
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(delay):
+	}
+}
+
+// hostOf extracts the host portion of a URL, returning the raw string
+// unchanged if it fails to parse (it's still a usable, if imprecise, rate-limit key).
+func hostOf(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return rawURL
+	}
+	return u.Host
+}
+
+// hostRateLimiter enforces a minimum interval between requests to the same
+// host, so concurrent workers don't overwhelm a single origin site.
+type hostRateLimiter struct {
+	minInterval time.Duration
+
+	mu   sync.Mutex
+	next map[string]time.Time
+}
+
+// newHostRateLimiter builds a hostRateLimiter enforcing minInterval between
+// requests to the same host.
+func newHostRateLimiter(minInterval time.Duration) *hostRateLimiter {
+	return &hostRateLimiter{
+		minInterval: minInterval,
+		next:        make(map[string]time.Time),
+	}
+}
+
+// Wait blocks until it is this host's turn to send a request, or ctx is cancelled.
+//
+// Parameters:
+// - ctx: the context governing cancellation of the wait.
+// - rawURL: the URL whose host is being rate limited.
+//
+// Returns:
+// - error: ctx.Err() if ctx is cancelled before the wait completes, nil otherwise.
+func (l *hostRateLimiter) Wait(ctx context.Context, rawURL string) error {
+	host := hostOf(rawURL)
+
+	l.mu.Lock()
+	now := time.Now()
+	wait := time.Duration(0)
+	if next, ok := l.next[host]; ok && next.After(now) {
+		wait = next.Sub(now)
+	}
+	l.next[host] = now.Add(wait).Add(l.minInterval)
+	l.mu.Unlock()
+
+	if wait <= 0 {
+		return nil
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(wait):
+		return nil
+	}
+}
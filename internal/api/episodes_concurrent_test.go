@@ -0,0 +1,29 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestIsRetryableFetchError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"429 too many requests", &httpStatusError{statusCode: http.StatusTooManyRequests}, true},
+		{"500 internal server error", &httpStatusError{statusCode: http.StatusInternalServerError}, true},
+		{"503 service unavailable", &httpStatusError{statusCode: http.StatusServiceUnavailable}, true},
+		{"404 not found", &httpStatusError{statusCode: http.StatusNotFound}, false},
+		{"plain network error", errors.New("connection reset"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isRetryableFetchError(tt.err); got != tt.want {
+				t.Errorf("isRetryableFetchError(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package feed
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// cacheDir is where rendered feeds are cached on disk, keyed by anime URL and
+// format so repeated feed requests don't hammer the origin site.
+const cacheDir = ".goanime/feed-cache"
+
+// cacheTTL is how long a cached feed is considered fresh before it's
+// rebuilt from the current episode list.
+const cacheTTL = 15 * time.Minute
+
+// cacheKey derives the cache file name for an anime URL and feed format.
+//
+// Parameters:
+// - animeURL: the anime's page URL.
+// - format: a short tag ("rss"/"atom") distinguishing cache entries for the same anime.
+//
+// Returns:
+// - string: the cache file path.
+func cacheKey(animeURL, format string) string {
+	sum := sha1.Sum([]byte(animeURL))
+	return filepath.Join(cacheDir, hex.EncodeToString(sum[:])+"."+format)
+}
+
+// readCache returns the cached feed body for animeURL/format, if present and
+// not older than cacheTTL.
+//
+// Parameters:
+// - animeURL: the anime's page URL.
+// - format: a short tag ("rss"/"atom") distinguishing cache entries for the same anime.
+//
+// Returns:
+// - string: the cached feed body, if found.
+// - bool: whether a fresh cache entry was found.
+func readCache(animeURL, format string) (string, bool) {
+	path := cacheKey(animeURL, format)
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", false
+	}
+	if time.Since(info.ModTime()) > cacheTTL {
+		return "", false
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", false
+	}
+	return string(data), true
+}
+
+// writeCache stores a rendered feed body for animeURL/format. Failures are
+// logged rather than returned, since a cache write failure shouldn't prevent
+// the feed from being served.
+//
+// Parameters:
+// - animeURL: the anime's page URL.
+// - format: a short tag ("rss"/"atom") distinguishing cache entries for the same anime.
+// - body: the rendered feed body to cache.
+func writeCache(animeURL, format, body string) {
+	path := cacheKey(animeURL, format)
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		log.Printf("Failed to create feed cache directory: %v", err)
+		return
+	}
+	if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+		log.Printf("Failed to write feed cache entry: %v", err)
+	}
+}
@@ -0,0 +1,130 @@
+// Package feed turns an anime's episode list into subscribable RSS and Atom
+// feeds, so users get notified of new episodes in their feed reader instead
+// of polling the source site by hand.
+package feed
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gorilla/feeds"
+	"github.com/pkg/errors"
+	"github.com/the-eduardo/GoAnime/internal/api"
+)
+
+// BuildFeed turns episodes into a feeds.Feed describing animeTitle, ready to
+// be rendered as RSS or Atom. Each item carries the episode's title, link,
+// pubDate (from its air date, when known) and a thumbnail enclosure.
+//
+// Parameters:
+// - animeTitle: the anime's display title, used as the feed title.
+// - animeURL: the anime's page URL, used as the feed link.
+// - episodes: the episodes to include, most recent last.
+//
+// Returns:
+// - *feeds.Feed: the built feed.
+// - error: an error if episodes is empty.
+func BuildFeed(animeTitle, animeURL string, episodes []api.Episode) (*feeds.Feed, error) {
+	if len(episodes) == 0 {
+		return nil, errors.New("no episodes to build a feed from")
+	}
+
+	feed := &feeds.Feed{
+		Title:   animeTitle,
+		Link:    &feeds.Link{Href: animeURL},
+		Created: time.Now(),
+	}
+
+	for _, ep := range episodes {
+		title := ep.Title
+		if title == "" {
+			title = "Episode " + ep.Number
+		}
+
+		item := &feeds.Item{
+			Title:   title,
+			Link:    &feeds.Link{Href: ep.URL},
+			Id:      ep.URL,
+			Created: ep.AirDate,
+		}
+
+		if ep.ThumbnailURL != "" {
+			item.Enclosure = &feeds.Enclosure{Url: ep.ThumbnailURL, Type: "image/jpeg"}
+		}
+
+		feed.Items = append(feed.Items, item)
+	}
+
+	return feed, nil
+}
+
+// ServeRSS writes episodes for the given anime as an RSS 2.0 feed.
+//
+// Parameters:
+// - w: the response writer to write the feed to.
+// - animeTitle: the anime's display title.
+// - animeURL: the anime's page URL, used to key the on-disk cache.
+// - episodes: the episodes to include.
+func ServeRSS(w http.ResponseWriter, animeTitle, animeURL string, episodes []api.Episode) error {
+	body, err := cachedRender(animeURL, "rss", func(f *feeds.Feed) (string, error) {
+		return f.ToRss()
+	}, animeTitle, episodes)
+	if err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/rss+xml; charset=utf-8")
+	_, err = w.Write([]byte(body))
+	return err
+}
+
+// ServeAtom writes episodes for the given anime as an Atom feed.
+//
+// Parameters:
+// - w: the response writer to write the feed to.
+// - animeTitle: the anime's display title.
+// - animeURL: the anime's page URL, used to key the on-disk cache.
+// - episodes: the episodes to include.
+func ServeAtom(w http.ResponseWriter, animeTitle, animeURL string, episodes []api.Episode) error {
+	body, err := cachedRender(animeURL, "atom", func(f *feeds.Feed) (string, error) {
+		return f.ToAtom()
+	}, animeTitle, episodes)
+	if err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/atom+xml; charset=utf-8")
+	_, err = w.Write([]byte(body))
+	return err
+}
+
+// cachedRender renders a feed with render, going through the on-disk cache
+// first so repeated requests for the same anime/format don't rebuild the
+// feed on every hit.
+//
+// Parameters:
+// - animeURL: the anime's page URL, used as the cache key.
+// - format: a short tag ("rss"/"atom") distinguishing cache entries for the same anime.
+// - render: the function that serializes a built feed to its final string form.
+// - animeTitle: the anime's display title, used if the feed needs to be (re)built.
+// - episodes: the episodes to include, used if the feed needs to be (re)built.
+//
+// Returns:
+// - string: the rendered feed body.
+// - error: an error if the feed couldn't be built or rendered; a failed cache write is logged, not returned.
+func cachedRender(animeURL, format string, render func(*feeds.Feed) (string, error), animeTitle string, episodes []api.Episode) (string, error) {
+	if body, ok := readCache(animeURL, format); ok {
+		return body, nil
+	}
+
+	feed, err := BuildFeed(animeTitle, animeURL, episodes)
+	if err != nil {
+		return "", err
+	}
+
+	body, err := render(feed)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to render feed")
+	}
+
+	writeCache(animeURL, format, body)
+	return body, nil
+}
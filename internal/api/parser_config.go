@@ -0,0 +1,164 @@
+package api
+
+import (
+	"net/url"
+	"regexp"
+	"strconv"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// ParserConfig describes how to extract an episode number out of the raw
+// label a source site uses for its episode links, so sites that don't use
+// plain "12"/"Episode 12" labels can plug in their own patterns without
+// recompiling the package.
+//
+// RemoveRegex, if set, is applied to the raw label first to strip noise
+// (site name, surrounding whitespace, etc.) that would otherwise confuse the
+// match; EpisodeRegex is then matched against what remains.
+//
+// EpisodeRegex must define a "episode" capture group, and may additionally
+// define "season" and "part" groups; "part" captures the fractional half of
+// a half-episode label such as "Episódio 12.5".
+type ParserConfig struct {
+	EpisodeRegex *regexp.Regexp
+	RemoveRegex  *regexp.Regexp
+}
+
+// DefaultParserConfig is the fallback configuration used when no
+// site-specific ParserConfig has been registered for a domain. It matches
+// plain numbers, "S01E12" labels (also recognized by parseEpisodeNumber) and
+// "12.5" style half-episode labels.
+var DefaultParserConfig = ParserConfig{
+	EpisodeRegex: regexp.MustCompile(`(?i)(?:S(?P<season>\d+)\s*E)?(?P<episode>\d+)(?:\.(?P<part>\d+))?`),
+}
+
+// EpisodeParser parses raw episode labels into structured numbers according
+// to a ParserConfig, so each source site can define its own label format.
+type EpisodeParser struct {
+	cfg ParserConfig
+}
+
+// NewEpisodeParser builds an EpisodeParser for the given config.
+//
+// Parameters:
+// - cfg: the ParserConfig describing how to extract episode numbers.
+//
+// Returns:
+// - *EpisodeParser: a parser using cfg.
+func NewEpisodeParser(cfg ParserConfig) *EpisodeParser {
+	return &EpisodeParser{cfg: cfg}
+}
+
+// ParsedEpisodeNumber is the structured result of parsing a raw episode label.
+type ParsedEpisodeNumber struct {
+	// Season is the season number, or 0 if the label didn't specify one.
+	Season int
+	// Num is the whole-number part of the episode number.
+	Num int
+	// Part is the fractional half of a half-episode label (e.g. 5 for "12.5"),
+	// or 0 for a regular, whole episode.
+	Part int
+}
+
+// Float returns the episode number as a float, so "12.5" round-trips as 12.5
+// rather than being truncated to 12.
+func (p ParsedEpisodeNumber) Float() float64 {
+	if p.Part == 0 {
+		return float64(p.Num)
+	}
+	whole := float64(p.Num)
+	frac := float64(p.Part)
+	for frac >= 1 {
+		frac /= 10
+	}
+	return whole + frac
+}
+
+// Parse extracts a ParsedEpisodeNumber out of a raw episode label, applying
+// the parser's RemoveRegex (if any) before matching EpisodeRegex.
+//
+// Parameters:
+// - raw: the raw episode label scraped from the page.
+//
+// Returns:
+// - ParsedEpisodeNumber: the parsed season/episode/part numbers.
+// - error: an error if raw doesn't match the configured EpisodeRegex.
+func (p *EpisodeParser) Parse(raw string) (ParsedEpisodeNumber, error) {
+	cleaned := raw
+	if p.cfg.RemoveRegex != nil {
+		cleaned = p.cfg.RemoveRegex.ReplaceAllString(cleaned, "")
+	}
+
+	re := p.cfg.EpisodeRegex
+	match := re.FindStringSubmatch(cleaned)
+	if match == nil {
+		return ParsedEpisodeNumber{}, errors.Errorf("no episode number found in %q", raw)
+	}
+
+	var result ParsedEpisodeNumber
+	for i, name := range re.SubexpNames() {
+		if name == "" || match[i] == "" {
+			continue
+		}
+		n, err := strconv.Atoi(match[i])
+		if err != nil {
+			continue
+		}
+		switch name {
+		case "season":
+			result.Season = n
+		case "episode":
+			result.Num = n
+		case "part":
+			result.Part = n
+		}
+	}
+
+	if result.Num == 0 && result.Season == 0 && result.Part == 0 {
+		return ParsedEpisodeNumber{}, errors.Errorf("no episode number found in %q", raw)
+	}
+
+	return result, nil
+}
+
+// parserRegistry holds the ParserConfig registered per source domain, so
+// GetParser can hand back the right EpisodeParser for a given anime URL.
+var (
+	parserRegistryMu sync.RWMutex
+	parserRegistry   = map[string]ParserConfig{}
+)
+
+// RegisterParser registers a ParserConfig to use for anime URLs on the given
+// domain, overriding DefaultParserConfig for that domain.
+//
+// Parameters:
+// - domain: the source site's domain (e.g. "example.com").
+// - cfg: the ParserConfig to use for that domain.
+func RegisterParser(domain string, cfg ParserConfig) {
+	parserRegistryMu.Lock()
+	defer parserRegistryMu.Unlock()
+	parserRegistry[domain] = cfg
+}
+
+// GetParser returns the EpisodeParser registered for animeURL's domain,
+// falling back to DefaultParserConfig when no site-specific config was
+// registered.
+//
+// Parameters:
+// - animeURL: the URL of the anime's page.
+//
+// Returns:
+// - *EpisodeParser: the parser to use for animeURL.
+func GetParser(animeURL string) *EpisodeParser {
+	parserRegistryMu.RLock()
+	defer parserRegistryMu.RUnlock()
+
+	if u, err := url.Parse(animeURL); err == nil {
+		if cfg, ok := parserRegistry[u.Hostname()]; ok {
+			return NewEpisodeParser(cfg)
+		}
+	}
+	return NewEpisodeParser(DefaultParserConfig)
+}
@@ -0,0 +1,53 @@
+package api
+
+import (
+	"testing"
+)
+
+func TestEpisodeParser_Parse(t *testing.T) {
+	parser := NewEpisodeParser(DefaultParserConfig)
+
+	tests := []struct {
+		name string
+		raw  string
+		want ParsedEpisodeNumber
+	}{
+		{"plain number", "12", ParsedEpisodeNumber{Num: 12}},
+		{"season and episode", "S02E07", ParsedEpisodeNumber{Season: 2, Num: 7}},
+		{"half episode", "12.5", ParsedEpisodeNumber{Num: 12, Part: 5}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parser.Parse(tt.raw)
+			if err != nil {
+				t.Fatalf("Parse(%q) returned an error: %v", tt.raw, err)
+			}
+			if got != tt.want {
+				t.Errorf("Parse(%q) = %+v, want %+v", tt.raw, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEpisodeParser_ParseNoMatch(t *testing.T) {
+	parser := NewEpisodeParser(ParserConfig{EpisodeRegex: seasonEpisodeRe})
+	if _, err := parser.Parse("no numbers here"); err == nil {
+		t.Error("expected an error for a label with no episode number, got nil")
+	}
+}
+
+func TestRegisterParser(t *testing.T) {
+	const domain = "parser-config-test.example"
+	cfg := ParserConfig{EpisodeRegex: DefaultParserConfig.EpisodeRegex}
+	RegisterParser(domain, cfg)
+
+	got := GetParser("https://" + domain + "/anime/1")
+	want, err := got.Parse("Episódio 3")
+	if err != nil {
+		t.Fatalf("Parse returned an error: %v", err)
+	}
+	if want.Num != 3 {
+		t.Errorf("got episode number %d, want 3", want.Num)
+	}
+}
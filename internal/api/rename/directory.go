@@ -0,0 +1,70 @@
+package rename
+
+import (
+	"io/fs"
+	"log"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// videoExtensions lists the file extensions RenameDirectory treats as
+// episode files worth renaming; anything else is skipped.
+var videoExtensions = map[string]bool{
+	".mp4":  true,
+	".mkv":  true,
+	".avi":  true,
+	".webm": true,
+}
+
+// RenameDirectory walks root and applies RenameEpisodeFile to every video
+// file found, skipping files whose name doesn't match opts' filename
+// pattern rather than failing the whole walk.
+//
+// The episode list for opts.AnimeURL is fetched once, up front, and reused
+// for every file in the tree rather than re-fetched per file.
+//
+// Parameters:
+// - root: the directory to walk.
+// - opts: options controlling parsing, lookup and the output format; see RenameEpisodeFile.
+//
+// Returns:
+// - []string: the new path for each file renamed (or that would be renamed, in dry-run mode).
+// - error: an error if the episode lookup or the directory walk itself fails.
+func RenameDirectory(root string, opts RenameOptions) ([]string, error) {
+	if opts.Episodes == nil {
+		episodes, err := episodesFor(opts)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to look up episode metadata")
+		}
+		opts.Episodes = episodes
+	}
+
+	var renamed []string
+
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		if !videoExtensions[strings.ToLower(filepath.Ext(path))] {
+			return nil
+		}
+
+		newPath, err := RenameEpisodeFile(path, opts)
+		if err != nil {
+			log.Printf("Skipping %s: %v", path, err)
+			return nil
+		}
+		renamed = append(renamed, newPath)
+		return nil
+	})
+	if err != nil {
+		return renamed, err
+	}
+
+	return renamed, nil
+}
@@ -0,0 +1,240 @@
+// Package rename turns the scraper into a Sonarr-lite for the anime source
+// it targets: it parses a local video file's name, looks up the matching
+// Episode via the api package, and renames the file to a consistent,
+// metadata-rich format.
+package rename
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/the-eduardo/GoAnime/internal/api"
+)
+
+// defaultFilenameRegex matches "Show Name - S01E12" / "Show Name - 12" style
+// local filenames, capturing the show name, optional season, and episode number.
+var defaultFilenameRegex = regexp.MustCompile(`(?i)^(?P<show>.+?)[\s._-]+(?:S(?P<season>\d+)[\s._-]*)?E?(?P<episode>\d+)`)
+
+// defaultFormat is the rename template used when RenameOptions.Format is empty.
+const defaultFormat = "{show} - {season}x{episode} - {title}{ext}"
+
+// RenameOptions configures how a local episode file is parsed and renamed.
+type RenameOptions struct {
+	// AnimeURL is the anime's page URL, used to look up episode metadata.
+	AnimeURL string
+	// Detailed selects GetAnimeEpisodesDetailed over GetAnimeEpisodes when
+	// looking up episode metadata, so the rename format can use {title}.
+	Detailed bool
+	// FilenameRegex overrides defaultFilenameRegex for parsing the local file name.
+	// It must define "show" and "episode" capture groups, and may define "season".
+	FilenameRegex *regexp.Regexp
+	// Format is the rename template. Supported placeholders are {show},
+	// {season}, {episode} (zero-padded to 2 digits), {title} and {ext}.
+	Format string
+	// DryRun computes the new path without touching the filesystem.
+	DryRun bool
+	// Episodes overrides the AnimeURL lookup with an already-fetched episode
+	// list. RenameDirectory sets this so a whole directory of files for the
+	// same anime only costs one fetch instead of one per file.
+	Episodes []api.Episode
+}
+
+// episodesFor returns opts.Episodes if set, otherwise fetches the episode
+// list for opts.AnimeURL.
+//
+// Parameters:
+// - opts: the options carrying either a pre-fetched episode list or an AnimeURL to fetch.
+//
+// Returns:
+// - []api.Episode: the episode list to search.
+// - error: an error if a fetch was needed and failed.
+func episodesFor(opts RenameOptions) ([]api.Episode, error) {
+	if opts.Episodes != nil {
+		return opts.Episodes, nil
+	}
+	if opts.Detailed {
+		return api.GetAnimeEpisodesDetailed(opts.AnimeURL)
+	}
+	return api.GetAnimeEpisodes(opts.AnimeURL)
+}
+
+// parsedFilename is the result of parsing a local episode file's name.
+type parsedFilename struct {
+	show    string
+	season  int
+	episode int
+}
+
+// displaySeason is the season number to use in the rename template: season,
+// or 1 for a single-season show whose filename doesn't mention one.
+func (p parsedFilename) displaySeason() int {
+	if p.season == 0 {
+		return 1
+	}
+	return p.season
+}
+
+// parseEpisodeFilename extracts the show name, season and episode number out
+// of a local video file's base name, using the given regex.
+//
+// Parameters:
+// - name: the file's base name (without directory).
+// - nameRegex: the regex used to parse name; must define "show" and "episode" groups.
+//
+// Returns:
+// - parsedFilename: the extracted show name, season and episode number.
+// - error: an error if name doesn't match nameRegex.
+func parseEpisodeFilename(name string, nameRegex *regexp.Regexp) (parsedFilename, error) {
+	ext := filepath.Ext(name)
+	base := strings.TrimSuffix(name, ext)
+
+	match := nameRegex.FindStringSubmatch(base)
+	if match == nil {
+		return parsedFilename{}, errors.Errorf("filename %q doesn't match the configured pattern", name)
+	}
+
+	var result parsedFilename
+	for i, groupName := range nameRegex.SubexpNames() {
+		if groupName == "" || match[i] == "" {
+			continue
+		}
+		switch groupName {
+		case "show":
+			result.show = strings.TrimSpace(strings.NewReplacer("_", " ", ".", " ").Replace(match[i]))
+		case "season":
+			result.season, _ = strconv.Atoi(match[i])
+		case "episode":
+			result.episode, _ = strconv.Atoi(match[i])
+		}
+	}
+
+	if result.show == "" {
+		return parsedFilename{}, errors.Errorf("filename %q has no recognizable show name", name)
+	}
+
+	return result, nil
+}
+
+// findEpisode locates the Episode matching episodeNum among episodes.
+//
+// Note this intentionally does not restrict the search by the season parsed
+// out of the local filename: GroupBySeason/ToSeasonedEpisode derive Season
+// from the scraped Episode.Number label (only nonzero when the source page
+// itself writes "S01E12"-style text), which is a different axis than the
+// season a filename's "SxxExx" convention encodes. GetAnimeEpisodes's own
+// selector only ever yields plain numeric labels, so for a typical
+// single-season anime every episode sits in season 0 regardless of what a
+// "Show - S01E05.mkv" file name says — restricting the lookup to that parsed
+// season would fail to find an episode that's right there. If multi-season
+// lookups need disambiguating, it has to be done against season data scraped
+// from the same source as episodeNum, not the filename's season.
+//
+// Parameters:
+// - episodes: the anime's episode list to search.
+// - episodeNum: the episode number to find.
+//
+// Returns:
+// - api.Episode: the matching episode.
+// - error: an error if no episode has that number.
+func findEpisode(episodes []api.Episode, episodeNum int) (api.Episode, error) {
+	for _, ep := range episodes {
+		if ep.Num == episodeNum {
+			return ep, nil
+		}
+	}
+	return api.Episode{}, errors.Errorf("no episode numbered %d found", episodeNum)
+}
+
+// sanitizeTitle strips path separators out of an episode title so it can be
+// safely used as part of a file name.
+//
+// Parameters:
+// - title: the raw episode title.
+//
+// Returns:
+// - string: title with '/' and '\' replaced by '-'.
+func sanitizeTitle(title string) string {
+	replacer := strings.NewReplacer("/", "-", "\\", "-")
+	return replacer.Replace(title)
+}
+
+// renderFormat fills in a rename template with the parsed filename, episode
+// metadata and original file extension.
+//
+// Parameters:
+// - format: the rename template; see RenameOptions.Format.
+// - parsed: the show name, season and episode number parsed from the original file name.
+// - ep: the looked-up Episode metadata.
+// - ext: the original file's extension, including the leading dot.
+//
+// Returns:
+// - string: the rendered file name.
+func renderFormat(format string, parsed parsedFilename, ep api.Episode, ext string) string {
+	replacer := strings.NewReplacer(
+		"{show}", parsed.show,
+		"{season}", fmt.Sprintf("%d", parsed.displaySeason()),
+		"{episode}", fmt.Sprintf("%02d", parsed.episode),
+		"{title}", sanitizeTitle(ep.Title),
+		"{ext}", ext,
+	)
+	return replacer.Replace(format)
+}
+
+// RenameEpisodeFile parses path's file name, looks up the matching Episode
+// for opts.AnimeURL, and returns the path renamed to opts.Format (or
+// defaultFormat if unset). Unless opts.DryRun is set, the file is renamed on
+// disk to the returned path, in the same directory as path.
+//
+// Parameters:
+// - path: the local video file's path.
+// - opts: options controlling parsing, lookup and the output format.
+//
+// Returns:
+// - string: the new path (computed even in dry-run mode).
+// - error: an error if the file name can't be parsed, no matching episode is found, or the rename fails.
+func RenameEpisodeFile(path string, opts RenameOptions) (string, error) {
+	nameRegex := opts.FilenameRegex
+	if nameRegex == nil {
+		nameRegex = defaultFilenameRegex
+	}
+	format := opts.Format
+	if format == "" {
+		format = defaultFormat
+	}
+
+	dir := filepath.Dir(path)
+	name := filepath.Base(path)
+	ext := filepath.Ext(name)
+
+	parsed, err := parseEpisodeFilename(name, nameRegex)
+	if err != nil {
+		return "", err
+	}
+
+	episodes, err := episodesFor(opts)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to look up episode metadata")
+	}
+
+	ep, err := findEpisode(episodes, parsed.episode)
+	if err != nil {
+		return "", err
+	}
+
+	newName := renderFormat(format, parsed, ep, ext)
+	newPath := filepath.Join(dir, newName)
+
+	if opts.DryRun || newPath == path {
+		return newPath, nil
+	}
+
+	if err := os.Rename(path, newPath); err != nil {
+		return "", errors.Wrap(err, "failed to rename file")
+	}
+	return newPath, nil
+}
@@ -0,0 +1,53 @@
+package rename
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/the-eduardo/GoAnime/internal/api"
+)
+
+// TestRenameEpisodeFile_SingleSeasonSxxExxFilename exercises the common case
+// a prior "season-aware" findEpisode change broke: a single-season anime
+// whose episodes are scraped with plain numeric labels (no "S01E05" text),
+// renamed from a local file that does use the SxxExx convention. The
+// filename's season must not be used to restrict the episode lookup.
+func TestRenameEpisodeFile_SingleSeasonSxxExxFilename(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "Show - S01E05.mkv")
+	if err := os.WriteFile(path, []byte("data"), 0o644); err != nil {
+		t.Fatalf("failed to write fixture file: %v", err)
+	}
+
+	episodes := []api.Episode{
+		{Number: "5", Num: 5, URL: "https://example.com/show/5", Title: "The Reckoning"},
+	}
+
+	newPath, err := RenameEpisodeFile(path, RenameOptions{
+		Episodes: episodes,
+		DryRun:   true,
+	})
+	if err != nil {
+		t.Fatalf("RenameEpisodeFile returned an error: %v", err)
+	}
+
+	want := filepath.Join(dir, "Show - 1x05 - The Reckoning.mkv")
+	if newPath != want {
+		t.Errorf("got new path %q, want %q", newPath, want)
+	}
+}
+
+func TestFindEpisode(t *testing.T) {
+	episodes := []api.Episode{
+		{Num: 1},
+		{Num: 5},
+	}
+
+	if _, err := findEpisode(episodes, 5); err != nil {
+		t.Errorf("expected to find episode 5, got error: %v", err)
+	}
+	if _, err := findEpisode(episodes, 99); err == nil {
+		t.Error("expected an error for a missing episode number, got nil")
+	}
+}
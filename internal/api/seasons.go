@@ -0,0 +1,177 @@
+package api
+
+import (
+	"regexp"
+	"strconv"
+)
+
+// EpisodeKind classifies an episode entry beyond its plain number, so
+// specials, OVAs and movies don't get lumped in with regular episodes.
+type EpisodeKind string
+
+const (
+	KindRegular EpisodeKind = "regular"
+	KindSpecial EpisodeKind = "special"
+	KindOVA     EpisodeKind = "ova"
+	KindMovie   EpisodeKind = "movie"
+	KindRecap   EpisodeKind = "recap"
+)
+
+// SeasonedEpisode is an Episode placed within a season/kind structure, for
+// sources that mix regular episodes with specials, OVAs, movies and
+// multi-season runs rather than numbering everything sequentially.
+type SeasonedEpisode struct {
+	Episode
+
+	// Season is the 1-based season number, or 0 when the source doesn't
+	// expose seasons and every episode belongs to a single run.
+	Season int
+	// EpisodeInSeason is the 1-based episode number within Season.
+	EpisodeInSeason int
+	// AbsoluteNum is the episode's parsed Num. It is not renumbered across
+	// seasons (for an "S02E03" label it is 3), and specials/OVAs/movies keep
+	// their own Num rather than being left out.
+	AbsoluteNum int
+	// Kind classifies the entry as a regular episode, special, OVA, movie or recap.
+	Kind EpisodeKind
+}
+
+// EpisodeCount tallies how many episodes of each kind an anime has, mirroring
+// the regular/special/OVA/movie categorization used by anidb-style databases.
+type EpisodeCount struct {
+	Regular int
+	Special int
+	OVA     int
+	Movie   int
+	Recap   int
+}
+
+// seasonEpisodeRe matches "S01E12" style episode numbers, capturing the
+// season and episode-in-season parts.
+var seasonEpisodeRe = regexp.MustCompile(`(?i)S(\d+)\s*E(\d+)`)
+
+// specialRe matches "Especial 3" / "Special 3" style entries.
+var specialRe = regexp.MustCompile(`(?i)Especial|Special`)
+
+// ovaRe matches "OVA 1" style entries.
+var ovaRe = regexp.MustCompile(`(?i)OVA`)
+
+// movieRe matches "Filme 2" / "Movie 2" style entries.
+var movieRe = regexp.MustCompile(`(?i)Filme|Movie`)
+
+// recapRe matches entries explicitly labeled as a recap.
+var recapRe = regexp.MustCompile(`(?i)Recap|Resumo`)
+
+// ToSeasonedEpisode classifies an Episode's raw Number label into a
+// SeasonedEpisode, recognizing "S01E12", "Especial 3", "Filme 2" and "OVA 1"
+// style patterns. Episodes that don't match any of these patterns are
+// treated as regular episodes in season 0, numbered by their parsed Num.
+//
+// Parameters:
+// - e: the Episode to classify.
+//
+// Returns:
+// - SeasonedEpisode: the episode enriched with season, kind and numbering info.
+func ToSeasonedEpisode(e Episode) SeasonedEpisode {
+	label := e.Number
+
+	if m := seasonEpisodeRe.FindStringSubmatch(label); m != nil {
+		season, _ := strconv.Atoi(m[1])
+		episodeInSeason, _ := strconv.Atoi(m[2])
+		return SeasonedEpisode{
+			Episode:         e,
+			Season:          season,
+			EpisodeInSeason: episodeInSeason,
+			AbsoluteNum:     e.Num,
+			Kind:            KindRegular,
+		}
+	}
+
+	kind := KindRegular
+	switch {
+	case specialRe.MatchString(label):
+		kind = KindSpecial
+	case ovaRe.MatchString(label):
+		kind = KindOVA
+	case movieRe.MatchString(label):
+		kind = KindMovie
+	case recapRe.MatchString(label):
+		kind = KindRecap
+	}
+
+	return SeasonedEpisode{
+		Episode:         e,
+		Season:          0,
+		EpisodeInSeason: e.Num,
+		AbsoluteNum:     e.Num,
+		Kind:            kind,
+	}
+}
+
+// GroupBySeason classifies each episode with ToSeasonedEpisode and groups
+// the originals by season number. Specials, OVAs and movies are grouped
+// under season 0 alongside any episode the source didn't tag with a season.
+//
+// Parameters:
+// - episodes: the episodes to group.
+//
+// Returns:
+// - map[int][]Episode: episodes keyed by season number, each slice sorted stably by episode number.
+func GroupBySeason(episodes []Episode) map[int][]Episode {
+	grouped := make(map[int][]Episode)
+	for _, e := range episodes {
+		se := ToSeasonedEpisode(e)
+		season := se.Season
+		if se.Kind != KindRegular {
+			season = 0
+		}
+		grouped[season] = append(grouped[season], e)
+	}
+	for season := range grouped {
+		sortEpisodes(grouped[season], ByNumber)
+	}
+	return grouped
+}
+
+// CountEpisodes tallies episodes of each kind, mirroring the
+// regular/special/OVA/movie/recap breakdown used by anidb-style databases.
+//
+// Parameters:
+// - episodes: the episodes to tally.
+//
+// Returns:
+// - EpisodeCount: the count of episodes of each kind.
+func CountEpisodes(episodes []Episode) EpisodeCount {
+	var count EpisodeCount
+	for _, e := range episodes {
+		switch ToSeasonedEpisode(e).Kind {
+		case KindSpecial:
+			count.Special++
+		case KindOVA:
+			count.OVA++
+		case KindMovie:
+			count.Movie++
+		case KindRecap:
+			count.Recap++
+		default:
+			count.Regular++
+		}
+	}
+	return count
+}
+
+// BySeasonEpisode orders episodes by season, then by episode number within
+// the season, keeping specials/OVAs/movies (season 0) after every numbered season.
+func BySeasonEpisode(a, b Episode) bool {
+	sa, sb := ToSeasonedEpisode(a), ToSeasonedEpisode(b)
+	if sa.Season != sb.Season {
+		if sa.Season == 0 {
+			return false
+		}
+		if sb.Season == 0 {
+			return true
+		}
+		return sa.Season < sb.Season
+	}
+	return sa.EpisodeInSeason < sb.EpisodeInSeason
+}